@@ -0,0 +1,111 @@
+// Package cache provides a persistent, TTL-aware on-disk store for
+// BlockSec responses and the chainlist.org chain list, so that repeated
+// MCP calls for the same transaction or a lookup of the chain list don't
+// have to re-fetch and re-authenticate every time.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Store is a JSON-file-per-key cache rooted at a directory on disk.
+type Store struct {
+	dir string
+}
+
+// New creates a Store rooted at dir, creating the directory if it
+// doesn't already exist.
+func New(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %v", err)
+	}
+
+	return &Store{dir: dir}, nil
+}
+
+// entry is the on-disk representation of a cached value.
+type entry struct {
+	Value     json.RawMessage `json:"value"`
+	ExpiresAt *time.Time      `json:"expiresAt,omitempty"`
+}
+
+// Key builds a cache key from its component parts, e.g.
+// Key("trace", "1", "0xabc...").
+func Key(parts ...string) string {
+	return strings.Join(parts, ":")
+}
+
+// path returns the on-disk path for key, hashed so arbitrary key
+// contents (transaction hashes, endpoint names) are always valid
+// filenames.
+func (s *Store) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the cached value for key, if present and not expired.
+func (s *Store) Get(key string) (json.RawMessage, bool) {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, false
+	}
+
+	if e.ExpiresAt != nil && time.Now().After(*e.ExpiresAt) {
+		return nil, false
+	}
+
+	return e.Value, true
+}
+
+// Set stores value under key. A ttl of zero means the entry never
+// expires, which is appropriate for immutable data like a mined
+// transaction's trace.
+func (s *Store) Set(key string, value json.RawMessage, ttl time.Duration) error {
+	e := entry{Value: value}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		e.ExpiresAt = &expiresAt
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %v", err)
+	}
+
+	if err := os.WriteFile(s.path(key), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write cache entry: %v", err)
+	}
+
+	return nil
+}
+
+// Purge removes every cached entry from disk.
+func (s *Store) Purge() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read cache directory: %v", err)
+	}
+
+	for _, e := range entries {
+		if err := os.Remove(filepath.Join(s.dir, e.Name())); err != nil {
+			return fmt.Errorf("failed to remove cache entry %s: %v", e.Name(), err)
+		}
+	}
+
+	return nil
+}
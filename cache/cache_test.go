@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return store
+}
+
+func TestSetGetRoundTrip(t *testing.T) {
+	store := newTestStore(t)
+	key := Key("trace", "1", "0xabc")
+
+	if err := store.Set(key, []byte(`{"ok":true}`), 0); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	got, ok := store.Get(key)
+	if !ok {
+		t.Fatalf("Get() ok = false, want true")
+	}
+	if string(got) != `{"ok":true}` {
+		t.Errorf("Get() = %s, want {\"ok\":true}", got)
+	}
+}
+
+func TestGetMissingKey(t *testing.T) {
+	store := newTestStore(t)
+
+	if _, ok := store.Get(Key("trace", "1", "0xmissing")); ok {
+		t.Errorf("Get() ok = true for a key never set, want false")
+	}
+}
+
+func TestSetZeroTTLNeverExpires(t *testing.T) {
+	store := newTestStore(t)
+	key := Key("trace", "1", "0xabc")
+
+	if err := store.Set(key, []byte(`"v"`), 0); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	// A zero TTL entry has no ExpiresAt, so it should still be there
+	// well past any real TTL window.
+	got, ok := store.Get(key)
+	if !ok || string(got) != `"v"` {
+		t.Errorf("Get() = (%s, %v), want (\"v\", true)", got, ok)
+	}
+
+	data, err := os.ReadFile(store.path(key))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if e.ExpiresAt != nil {
+		t.Errorf("ExpiresAt = %v for a zero TTL entry, want nil", e.ExpiresAt)
+	}
+}
+
+func TestSetExpiredTTLIsNotReturned(t *testing.T) {
+	store := newTestStore(t)
+	key := Key("chainlist")
+
+	if err := store.Set(key, []byte(`"v"`), time.Nanosecond); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	time.Sleep(time.Millisecond)
+
+	if _, ok := store.Get(key); ok {
+		t.Errorf("Get() ok = true for an expired entry, want false")
+	}
+}
+
+func TestKeyHashingProducesValidFilenames(t *testing.T) {
+	store := newTestStore(t)
+
+	// A key containing characters that aren't valid in a filename (":",
+	// "/") must still round-trip, since path() hashes it first.
+	key := Key("trace", "1", "0x../../etc/passwd")
+	if err := store.Set(key, []byte(`"v"`), 0); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(store.dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected exactly one file on disk, got %d", len(entries))
+	}
+	if filepath.Dir(filepath.Join(store.dir, entries[0].Name())) != store.dir {
+		t.Errorf("cache entry escaped the store directory: %s", entries[0].Name())
+	}
+
+	if _, ok := store.Get(key); !ok {
+		t.Errorf("Get() ok = false after Set() with the same key, want true")
+	}
+}
+
+func TestPurgeRemovesEverything(t *testing.T) {
+	store := newTestStore(t)
+
+	if err := store.Set(Key("trace", "1", "0xa"), []byte(`"a"`), 0); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := store.Set(Key("trace", "1", "0xb"), []byte(`"b"`), 0); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	if err := store.Purge(); err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+
+	if _, ok := store.Get(Key("trace", "1", "0xa")); ok {
+		t.Errorf("Get() ok = true after Purge(), want false")
+	}
+	if _, ok := store.Get(Key("trace", "1", "0xb")); ok {
+		t.Errorf("Get() ok = true after Purge(), want false")
+	}
+
+	entries, err := os.ReadDir(store.dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no files left after Purge(), got %d", len(entries))
+	}
+}
+
+func TestPurgeOnMissingDirectoryIsNotAnError(t *testing.T) {
+	store := &Store{dir: filepath.Join(t.TempDir(), "does-not-exist")}
+
+	if err := store.Purge(); err != nil {
+		t.Errorf("Purge() error = %v, want nil", err)
+	}
+}
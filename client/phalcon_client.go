@@ -1,18 +1,25 @@
+// Package client provides an in-process client for embedding the
+// Phalcon MCP server in a Go program without spawning it as a
+// subprocess. See examples/embed for a runnable example.
 package client
 
 import (
 	"context"
-	
+	"fmt"
+
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/phalcon-mcp/server"
 )
 
+// InProcessClient is an MCP client wired directly to a Phalcon MCP
+// server in the same process, skipping the stdio/HTTP transport layer.
 type InProcessClient struct {
 	mcpClient *client.Client
 	version   string
 }
 
+// NewInProcessClient creates an InProcessClient backed by phalconServer.
 func NewInProcessClient(phalconServer *server.Server) (*InProcessClient, error) {
 	mcpClient, err := client.NewInProcessClient(phalconServer.GetMCPServer())
 	if err != nil {
@@ -25,15 +32,17 @@ func NewInProcessClient(phalconServer *server.Server) (*InProcessClient, error)
 	}, nil
 }
 
+// Connect starts the underlying MCP client transport.
 func (c *InProcessClient) Connect(ctx context.Context) error {
 	return c.mcpClient.Start(ctx)
 }
 
+// Initialize performs the MCP initialize handshake.
 func (c *InProcessClient) Initialize(ctx context.Context) error {
 	initRequest := mcp.InitializeRequest{}
 	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
 	initRequest.Params.ClientInfo = mcp.Implementation{
-		Name:    "todo",
+		Name:    "phalcon-mcp-embed",
 		Version: c.version,
 	}
 
@@ -41,6 +50,7 @@ func (c *InProcessClient) Initialize(ctx context.Context) error {
 	return err
 }
 
+// ListTools returns every tool the server exposes.
 func (c *InProcessClient) ListTools(ctx context.Context) ([]mcp.Tool, error) {
 	tools, err := c.mcpClient.ListTools(ctx, mcp.ListToolsRequest{})
 	if err != nil {
@@ -50,14 +60,47 @@ func (c *InProcessClient) ListTools(ctx context.Context) ([]mcp.Tool, error) {
 	return tools.Tools, nil
 }
 
+// CallTool invokes toolName with arguments and returns the raw MCP
+// result. Prefer the typed helpers (Trace, Profile, TransactionOverview,
+// GetChainIDByName) unless the caller needs a tool this client doesn't
+// wrap yet.
 func (c *InProcessClient) CallTool(ctx context.Context, toolName string, arguments map[string]any) (*mcp.CallToolResult, error) {
 	request := mcp.CallToolRequest{}
-	request.Params.Name = "test-tool"
+	request.Params.Name = toolName
 	request.Params.Arguments = arguments
 
 	return c.mcpClient.CallTool(ctx, request)
 }
 
+// Close shuts down the underlying MCP client transport.
 func (c *InProcessClient) Close() error {
 	return c.mcpClient.Close()
 }
+
+// callText invokes toolName and returns its text content, or an error if
+// the call failed or the tool reported an error result.
+func (c *InProcessClient) callText(ctx context.Context, toolName string, arguments map[string]any) (string, error) {
+	result, err := c.CallTool(ctx, toolName, arguments)
+	if err != nil {
+		return "", err
+	}
+
+	text := textContent(result)
+	if result.IsError {
+		return "", fmt.Errorf("%s: %s", toolName, text)
+	}
+
+	return text, nil
+}
+
+// textContent extracts the text of a CallToolResult's first text content
+// block, or "" if it has none.
+func textContent(result *mcp.CallToolResult) string {
+	for _, c := range result.Content {
+		if tc, ok := c.(mcp.TextContent); ok {
+			return tc.Text
+		}
+	}
+
+	return ""
+}
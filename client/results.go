@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/phalcon-mcp/server"
+)
+
+// TraceResult holds a trace tool's response. Its Data shape depends on
+// which DataSource answered the call, so it's left as raw JSON for the
+// caller to decode further.
+type TraceResult struct {
+	Data json.RawMessage `json:"data"`
+}
+
+// ProfileResult holds a profile tool's response. Its Data shape depends
+// on which DataSource answered the call, so it's left as raw JSON for
+// the caller to decode further.
+type ProfileResult struct {
+	Data json.RawMessage `json:"data"`
+}
+
+// Trace calls the trace tool and returns its response as raw JSON.
+func (c *InProcessClient) Trace(ctx context.Context, chainId, txHash string) (TraceResult, error) {
+	text, err := c.callText(ctx, "trace", map[string]any{
+		"chainId":         chainId,
+		"transactionHash": txHash,
+	})
+	if err != nil {
+		return TraceResult{}, err
+	}
+
+	return TraceResult{Data: json.RawMessage(text)}, nil
+}
+
+// Profile calls the profile tool and returns its response as raw JSON.
+func (c *InProcessClient) Profile(ctx context.Context, chainId, txHash string) (ProfileResult, error) {
+	text, err := c.callText(ctx, "profile", map[string]any{
+		"chainId":         chainId,
+		"transactionHash": txHash,
+	})
+	if err != nil {
+		return ProfileResult{}, err
+	}
+
+	return ProfileResult{Data: json.RawMessage(text)}, nil
+}
+
+// TransactionOverview calls the transaction-overview tool and decodes its
+// response.
+func (c *InProcessClient) TransactionOverview(ctx context.Context, chainId, txHash string) (server.OverviewResult, error) {
+	text, err := c.callText(ctx, "transaction-overview", map[string]any{
+		"chainId":         chainId,
+		"transactionHash": txHash,
+	})
+	if err != nil {
+		return server.OverviewResult{}, err
+	}
+
+	var overview server.OverviewResult
+	if err := json.Unmarshal([]byte(text), &overview); err != nil {
+		return server.OverviewResult{}, fmt.Errorf("failed to unmarshal transaction overview: %v", err)
+	}
+
+	return overview, nil
+}
+
+// GetChainIDByName calls the get-chain-id-by-name tool and returns the
+// resolved chain ID.
+func (c *InProcessClient) GetChainIDByName(ctx context.Context, name string) (string, error) {
+	return c.callText(ctx, "get-chain-id-by-name", map[string]any{
+		"name": name,
+	})
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mark3labs/phalcon-mcp/cache"
+	"github.com/spf13/cobra"
+)
+
+var cacheDir string
+
+// defaultCacheDir returns the cache directory used when --cache-dir isn't
+// set explicitly.
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "phalcon-mcp")
+}
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the on-disk response cache",
+	Long:  `Manage the on-disk cache used to store BlockSec responses and the chain list.`,
+}
+
+// cachePurgeCmd represents the cache purge command
+var cachePurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Remove all cached entries",
+	Long:  `Remove every cached BlockSec response and chain list entry from disk.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		store, err := cache.New(cacheDir)
+		if err != nil {
+			fmt.Printf("Failed to open cache directory: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := store.Purge(); err != nil {
+			fmt.Printf("Failed to purge cache: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Purged cache at %s\n", cacheDir)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cachePurgeCmd)
+
+	cacheCmd.PersistentFlags().StringVar(&cacheDir, "cache-dir", defaultCacheDir(), "Directory to store cached responses in")
+}
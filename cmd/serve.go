@@ -7,22 +7,46 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	httpAddr       string
+	sseAddr        string
+	maxConcurrency int
+)
+
 // serveCmd represents the serve command
 var serveCmd = &cobra.Command{
 	Use:   "serve",
-	Short: "Start the MCP server in stdio mode",
-	Long:  `Start the Model Context Protocol (MCP) server in stdio mode.`,
+	Short: "Start the MCP server",
+	Long:  `Start the Model Context Protocol (MCP) server in stdio, Streamable HTTP, or HTTP+SSE mode.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Starting MCP server in stdio mode...")
+		// Create the server
+		s := server.NewServer(Version, cacheDir, maxConcurrency)
 
-		// Create and start the server
-		s := server.NewServer(Version)
-		if err := s.ServeStdio(); err != nil {
-			fmt.Printf("Server error: %v\n", err)
+		switch {
+		case httpAddr != "":
+			fmt.Printf("Starting MCP server in Streamable HTTP mode on %s...\n", httpAddr)
+			if err := s.ServeHTTP(httpAddr); err != nil {
+				fmt.Printf("Server error: %v\n", err)
+			}
+		case sseAddr != "":
+			fmt.Printf("Starting MCP server in HTTP+SSE mode on %s...\n", sseAddr)
+			if err := s.ServeSSE(sseAddr); err != nil {
+				fmt.Printf("Server error: %v\n", err)
+			}
+		default:
+			fmt.Println("Starting MCP server in stdio mode...")
+			if err := s.ServeStdio(); err != nil {
+				fmt.Printf("Server error: %v\n", err)
+			}
 		}
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(serveCmd)
-}
\ No newline at end of file
+
+	serveCmd.Flags().StringVar(&httpAddr, "http", "", "Serve the Streamable HTTP transport on the given address (e.g. :8080)")
+	serveCmd.Flags().StringVar(&sseAddr, "sse", "", "Serve the HTTP+SSE transport on the given address (e.g. :8080)")
+	serveCmd.Flags().StringVar(&cacheDir, "cache-dir", defaultCacheDir(), "Directory to cache BlockSec responses and the chain list in")
+	serveCmd.Flags().IntVar(&maxConcurrency, "max-concurrency", 8, "Maximum number of transactions a trace-batch/overview-batch call processes at once")
+}
@@ -0,0 +1,53 @@
+// Command embed shows how to pull Phalcon data from a Go program by
+// embedding the MCP server directly, without spawning it as a
+// subprocess or talking to it over stdio/HTTP.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/phalcon-mcp/client"
+	"github.com/mark3labs/phalcon-mcp/server"
+)
+
+func main() {
+	phalconServer := server.NewServer("embed-example", "", 0)
+
+	c, err := client.NewInProcessClient(phalconServer)
+	if err != nil {
+		log.Fatalf("failed to create in-process client: %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		log.Fatalf("failed to connect: %v", err)
+	}
+
+	if err := c.Initialize(ctx); err != nil {
+		log.Fatalf("failed to initialize: %v", err)
+	}
+
+	chainId, err := c.GetChainIDByName(ctx, "ethereum")
+	if err != nil {
+		log.Fatalf("failed to resolve chain ID: %v", err)
+	}
+	fmt.Printf("ethereum chain ID: %s\n", chainId)
+
+	const txHash = "0x0000000000000000000000000000000000000000000000000000000000000000"
+
+	overview, err := c.TransactionOverview(ctx, chainId, txHash)
+	if err != nil {
+		log.Fatalf("failed to fetch transaction overview: %v", err)
+	}
+
+	for tool, result := range overview.Results {
+		if result.Error != "" {
+			fmt.Printf("%s: error: %s\n", tool, result.Error)
+			continue
+		}
+		fmt.Printf("%s: %s\n", tool, result.Data)
+	}
+}
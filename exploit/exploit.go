@@ -0,0 +1,244 @@
+// Package exploit runs a small rule engine over a transaction's trace,
+// state-change, and balance-change data looking for patterns common to
+// on-chain exploits: reentrancy, flash-loan usage, oracle manipulation,
+// unusual admin calls, and disproportionate value outflows.
+//
+// The trace/state-change/balance-change payloads it consumes come from
+// whichever DataSource or local tracer answered the call, so field names
+// are read defensively rather than unmarshalled into a fixed schema.
+package exploit
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Severity indicates how confident a rule is that what it flagged
+// represents malicious activity.
+type Severity string
+
+const (
+	SeverityLow    Severity = "low"
+	SeverityMedium Severity = "medium"
+	SeverityHigh   Severity = "high"
+)
+
+// Evidence captures what tripped a rule, so a reviewer can verify it
+// without re-running the analysis.
+type Evidence struct {
+	Frame    string   `json:"frame,omitempty"`
+	Contract string   `json:"contract,omitempty"`
+	Values   []string `json:"values,omitempty"`
+}
+
+// Indicator is a single rule match against a transaction.
+type Indicator struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Evidence Evidence `json:"evidence"`
+}
+
+// Report is the structured result of analyzing a transaction.
+type Report struct {
+	Indicators []Indicator `json:"indicators"`
+	Severity   Severity    `json:"severity"`
+}
+
+// callFrame is a defensively-parsed call-tree node. Trace payloads come
+// from different backends (callTracer, Phalcon's own format) that don't
+// agree on field names, so every field is read via the first matching
+// alias it finds.
+type callFrame struct {
+	From     string
+	To       string
+	Method   string
+	Selector string
+	Children []callFrame
+}
+
+// balanceEntry is a defensively-parsed balance-change record.
+type balanceEntry struct {
+	Address string
+	Before  string
+	After   string
+}
+
+// stateChangeEntry is a defensively-parsed storage state-change record.
+type stateChangeEntry struct {
+	Address string
+	Slot    string
+	Before  string
+	After   string
+}
+
+// adminFunctionKeywords flags function names that typically gate
+// privileged, owner-only behavior.
+var adminFunctionKeywords = []string{
+	"setowner", "transferownership", "upgrade", "pause", "unpause",
+	"mint", "setadmin", "grantrole", "setfeeto", "emergencywithdraw",
+}
+
+// Analyze runs every rule over trace, stateChange, and balanceChange and
+// returns a report of what fired. Payloads that don't parse into a
+// recognizable shape are simply skipped by the rules that need them,
+// rather than causing an error - a partial report is more useful here
+// than no report at all.
+func Analyze(trace, stateChange, balanceChange []byte) (*Report, error) {
+	frames := parseCallFrames(trace)
+	flat := flattenFrames(frames)
+	balances := parseBalanceEntries(balanceChange)
+	stateChanges := parseStateChangeEntries(stateChange)
+
+	var indicators []Indicator
+	indicators = append(indicators, detectReentrancy(frames)...)
+	indicators = append(indicators, detectFlashLoan(flat)...)
+	indicators = append(indicators, detectOracleManipulation(frames)...)
+	indicators = append(indicators, detectAdminCalls(flat)...)
+	indicators = append(indicators, detectAdminStateChange(stateChanges)...)
+	indicators = append(indicators, detectDisproportionateOutflow(balances)...)
+
+	return &Report{
+		Indicators: indicators,
+		Severity:   overallSeverity(indicators),
+	}, nil
+}
+
+// flattenFrames returns every frame in the call tree in preorder - parent
+// before its own descendants - for rules that scan the whole trace
+// without caring about nesting.
+func flattenFrames(frames []callFrame) []callFrame {
+	var out []callFrame
+	for _, f := range frames {
+		out = append(out, f)
+		out = append(out, flattenFrames(f.Children)...)
+	}
+	return out
+}
+
+// overallSeverity is the highest severity among indicators, or empty
+// when none fired.
+func overallSeverity(indicators []Indicator) Severity {
+	severity := Severity("")
+	rank := map[Severity]int{SeverityLow: 1, SeverityMedium: 2, SeverityHigh: 3}
+
+	for _, i := range indicators {
+		if rank[i.Severity] > rank[severity] {
+			severity = i.Severity
+		}
+	}
+
+	return severity
+}
+
+// parseCallFrames walks a generic JSON call tree, tolerating whichever
+// of the common field-name variants a given backend used.
+func parseCallFrames(data []byte) []callFrame {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+
+	return walkCallFrames(raw)
+}
+
+func walkCallFrames(raw any) []callFrame {
+	switch v := raw.(type) {
+	case map[string]any:
+		frame := callFrame{
+			From:     firstString(v, "from", "From"),
+			To:       firstString(v, "to", "To"),
+			Method:   firstString(v, "functionName", "method", "function", "name"),
+			Selector: selectorOf(firstString(v, "input", "data", "Input")),
+		}
+
+		if calls, ok := v["calls"]; ok {
+			frame.Children = walkCallFrames(calls)
+		}
+
+		return []callFrame{frame}
+	case []any:
+		var frames []callFrame
+		for _, item := range v {
+			frames = append(frames, walkCallFrames(item)...)
+		}
+		return frames
+	default:
+		return nil
+	}
+}
+
+// parseBalanceEntries walks a generic JSON array of balance-change
+// records, tolerating whichever of the common field-name variants a
+// given backend used.
+func parseBalanceEntries(data []byte) []balanceEntry {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var raw []map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+
+	entries := make([]balanceEntry, 0, len(raw))
+	for _, v := range raw {
+		entries = append(entries, balanceEntry{
+			Address: firstString(v, "address", "Address", "account"),
+			Before:  firstString(v, "before", "Before", "from"),
+			After:   firstString(v, "after", "After", "to"),
+		})
+	}
+
+	return entries
+}
+
+// parseStateChangeEntries walks a generic JSON array of storage
+// state-change records, tolerating whichever of the common field-name
+// variants a given backend used.
+func parseStateChangeEntries(data []byte) []stateChangeEntry {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var raw []map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+
+	entries := make([]stateChangeEntry, 0, len(raw))
+	for _, v := range raw {
+		entries = append(entries, stateChangeEntry{
+			Address: firstString(v, "address", "Address", "account"),
+			Slot:    firstString(v, "slot", "Slot", "key", "storageKey"),
+			Before:  firstString(v, "before", "Before"),
+			After:   firstString(v, "after", "After"),
+		})
+	}
+
+	return entries
+}
+
+// firstString returns the first string-typed value found in v under any
+// of keys, or "" if none match.
+func firstString(v map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if s, ok := v[key].(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// selectorOf returns the 4-byte function selector from hex call data,
+// or "" if data is too short to contain one.
+func selectorOf(data string) string {
+	data = strings.TrimPrefix(data, "0x")
+	if len(data) < 8 {
+		return ""
+	}
+	return data[:8]
+}
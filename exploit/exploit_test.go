@@ -0,0 +1,239 @@
+package exploit
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal test fixture: %v", err)
+	}
+	return data
+}
+
+func hasRule(indicators []Indicator, rule string) bool {
+	for _, i := range indicators {
+		if i.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDetectReentrancy(t *testing.T) {
+	tests := []struct {
+		name string
+		root map[string]any
+		want bool
+	}{
+		{
+			name: "nested re-entrant call flags",
+			root: map[string]any{
+				"to": "0xAAA", "functionName": "withdraw",
+				"calls": []any{
+					map[string]any{
+						"to": "0xBBB", "functionName": "onWithdraw",
+						"calls": []any{
+							map[string]any{"to": "0xAAA", "functionName": "withdraw"},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "sibling calls to the same address don't flag",
+			root: map[string]any{
+				"to": "0xROUTER", "functionName": "multiSwap",
+				"calls": []any{
+					map[string]any{"to": "0xPOOLA", "functionName": "swap"},
+					map[string]any{"to": "0xPOOLA", "functionName": "swap"},
+				},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frames := parseCallFrames(mustJSON(t, tt.root))
+			got := hasRule(detectReentrancy(frames), "reentrancy")
+			if got != tt.want {
+				t.Errorf("detectReentrancy() flagged = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectOracleManipulation(t *testing.T) {
+	tests := []struct {
+		name string
+		root map[string]any
+		want bool
+	}{
+		{
+			name: "swap immediately followed by oracle read on same contract",
+			root: map[string]any{
+				"to": "0xCALLER", "functionName": "attack",
+				"calls": []any{
+					map[string]any{"to": "0xPOOLX", "functionName": "swap"},
+					map[string]any{"to": "0xPOOLX", "functionName": "getReserves"},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "swap with its own nested call still flags sibling oracle read",
+			root: map[string]any{
+				"to": "0xCALLER", "functionName": "attack",
+				"calls": []any{
+					map[string]any{
+						"to": "0xPOOLX", "functionName": "swap",
+						"calls": []any{
+							map[string]any{"to": "0xTOKEN", "functionName": "transfer"},
+						},
+					},
+					map[string]any{"to": "0xPOOLX", "functionName": "getReserves"},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "oracle read on a different contract doesn't flag",
+			root: map[string]any{
+				"to": "0xCALLER", "functionName": "attack",
+				"calls": []any{
+					map[string]any{"to": "0xPOOLX", "functionName": "swap"},
+					map[string]any{"to": "0xORACLE", "functionName": "getReserves"},
+				},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frames := parseCallFrames(mustJSON(t, tt.root))
+			got := hasRule(detectOracleManipulation(frames), "oracle-manipulation")
+			if got != tt.want {
+				t.Errorf("detectOracleManipulation() flagged = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectFlashLoan(t *testing.T) {
+	tests := []struct {
+		name string
+		root map[string]any
+		want bool
+	}{
+		{
+			name: "borrow and repay on the same pool flags",
+			root: map[string]any{
+				"to": "0xCALLER", "functionName": "attack",
+				"calls": []any{
+					map[string]any{"to": "0xPOOL", "functionName": "flashLoan"},
+					map[string]any{"to": "0xPOOL", "functionName": "repay"},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "lone repay on an ordinary lending pool doesn't flag",
+			root: map[string]any{
+				"to": "0xCALLER", "functionName": "attack",
+				"calls": []any{
+					map[string]any{"to": "0xLENDINGPOOL", "functionName": "repay"},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "borrow on one pool and repay on another doesn't flag",
+			root: map[string]any{
+				"to": "0xCALLER", "functionName": "attack",
+				"calls": []any{
+					map[string]any{"to": "0xPOOLA", "functionName": "flashLoan"},
+					map[string]any{"to": "0xPOOLB", "functionName": "repay"},
+				},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frames := flattenFrames(parseCallFrames(mustJSON(t, tt.root)))
+			got := hasRule(detectFlashLoan(frames), "flash-loan-usage")
+			if got != tt.want {
+				t.Errorf("detectFlashLoan() flagged = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectAdminStateChange(t *testing.T) {
+	tests := []struct {
+		name string
+		slot string
+		want bool
+	}{
+		{"admin slot write flags", "0x" + eip1967AdminSlot, true},
+		{"implementation slot write flags", eip1967ImplementationSlot, true},
+		{"unrelated slot doesn't flag", "0x0000000000000000000000000000000000000000000000000000000000000001", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := parseStateChangeEntries(mustJSON(t, []map[string]any{
+				{"address": "0xPROXY", "slot": tt.slot, "before": "0x0", "after": "0x1"},
+			}))
+			got := hasRule(detectAdminStateChange(changes), "admin-function-invocation")
+			if got != tt.want {
+				t.Errorf("detectAdminStateChange() flagged = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectDisproportionateOutflow(t *testing.T) {
+	balances := parseBalanceEntries(mustJSON(t, []map[string]any{
+		{"address": "0xVICTIM", "before": "1000", "after": "0"},
+		{"address": "0xOTHER", "before": "1000", "after": "990"},
+	}))
+
+	indicators := detectDisproportionateOutflow(balances)
+	if !hasRule(indicators, "disproportionate-outflow") {
+		t.Fatalf("expected disproportionate-outflow indicator, got %+v", indicators)
+	}
+
+	flagged := false
+	for _, i := range indicators {
+		if i.Evidence.Contract == "0xVICTIM" {
+			flagged = true
+		}
+		if i.Evidence.Contract == "0xOTHER" {
+			t.Errorf("did not expect 0xOTHER's small outflow to be flagged")
+		}
+	}
+	if !flagged {
+		t.Errorf("expected 0xVICTIM's outflow to be flagged, got %+v", indicators)
+	}
+}
+
+func TestAnalyzeReturnsEmptyReportOnUnparsableInput(t *testing.T) {
+	report, err := Analyze([]byte("not json"), nil, nil)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v, want nil", err)
+	}
+	if len(report.Indicators) != 0 {
+		t.Errorf("Analyze() indicators = %+v, want none", report.Indicators)
+	}
+	if report.Severity != "" {
+		t.Errorf("Analyze() severity = %q, want empty", report.Severity)
+	}
+}
@@ -0,0 +1,274 @@
+package exploit
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// detectReentrancy flags a contract that is called again while an
+// earlier call into it is still open on the call stack - true re-entrant
+// nesting, not just repeated calls. Sibling calls to the same address
+// (e.g. two legs of a multi-hop swap) don't count: the first call has
+// already returned by the time the second one starts.
+func detectReentrancy(frames []callFrame) []Indicator {
+	var indicators []Indicator
+	seen := make(map[string]bool)
+	walkForReentrancy(frames, nil, seen, &indicators)
+	return indicators
+}
+
+func walkForReentrancy(frames []callFrame, stack []string, seen map[string]bool, indicators *[]Indicator) {
+	for _, f := range frames {
+		addr := strings.ToLower(f.To)
+
+		if addr != "" && !seen[addr] {
+			for _, ancestor := range stack {
+				if ancestor != addr {
+					continue
+				}
+				seen[addr] = true
+				*indicators = append(*indicators, Indicator{
+					Rule:     "reentrancy",
+					Severity: SeverityHigh,
+					Evidence: Evidence{
+						Contract: addr,
+						Values:   []string{"re-entered while an earlier call into the same contract was still on the stack"},
+					},
+				})
+				break
+			}
+		}
+
+		childStack := stack
+		if addr != "" {
+			childStack = append(append([]string{}, stack...), addr)
+		}
+		walkForReentrancy(f.Children, childStack, seen, indicators)
+	}
+}
+
+// flashLoanBorrowKeywords and flashLoanRepayKeywords identify the two
+// halves of a flash loan. A lone "repay" (settling an ordinary loan) or
+// a lone "flash"/"borrow" call is routine; it's the same contract seeing
+// both within one transaction that's the flash-loan signal.
+var (
+	flashLoanBorrowKeywords = []string{"flashloan", "flash", "borrow"}
+	flashLoanRepayKeywords  = []string{"repay"}
+)
+
+// detectFlashLoan flags a pool/token contract that's the target of both
+// a borrow-like and a repay-like call within the same transaction -
+// correlated borrow+repay pairs, not a bare keyword match on either
+// alone.
+func detectFlashLoan(frames []callFrame) []Indicator {
+	borrowed := make(map[string]string)
+	repaid := make(map[string]string)
+
+	for _, f := range frames {
+		addr := strings.ToLower(f.To)
+		if addr == "" {
+			continue
+		}
+		if containsAny(f.Method, flashLoanBorrowKeywords) {
+			if _, ok := borrowed[addr]; !ok {
+				borrowed[addr] = f.Method
+			}
+		}
+		if containsAny(f.Method, flashLoanRepayKeywords) {
+			if _, ok := repaid[addr]; !ok {
+				repaid[addr] = f.Method
+			}
+		}
+	}
+
+	var indicators []Indicator
+	for addr, borrowMethod := range borrowed {
+		repayMethod, ok := repaid[addr]
+		if !ok {
+			continue
+		}
+
+		indicators = append(indicators, Indicator{
+			Rule:     "flash-loan-usage",
+			Severity: SeverityMedium,
+			Evidence: Evidence{
+				Contract: addr,
+				Values:   []string{borrowMethod, repayMethod},
+			},
+		})
+	}
+
+	return indicators
+}
+
+// oracleReadKeywords identifies functions that read a price or reserve
+// value from a pool or oracle.
+var oracleReadKeywords = []string{"getreserves", "latestanswer", "getprice", "slot0"}
+
+// swapKeywords identifies functions that execute a swap.
+var swapKeywords = []string{"swap"}
+
+// detectOracleManipulation flags a swap call immediately followed by an
+// oracle/reserve read on the same contract, the shape of a classic
+// sandwich-style price manipulation. "Immediately followed" means the
+// next call that shares its parent - i.e. made after the swap frame
+// itself returned - not the swap's own first nested call, which is
+// preorder-adjacent but hasn't returned yet.
+func detectOracleManipulation(frames []callFrame) []Indicator {
+	var indicators []Indicator
+	walkSiblingsForOracleManipulation(frames, &indicators)
+	return indicators
+}
+
+func walkSiblingsForOracleManipulation(siblings []callFrame, indicators *[]Indicator) {
+	for i, cur := range siblings {
+		if i+1 < len(siblings) {
+			next := siblings[i+1]
+			if containsAny(cur.Method, swapKeywords) &&
+				containsAny(next.Method, oracleReadKeywords) &&
+				strings.EqualFold(cur.To, next.To) {
+				*indicators = append(*indicators, Indicator{
+					Rule:     "oracle-manipulation",
+					Severity: SeverityHigh,
+					Evidence: Evidence{
+						Contract: cur.To,
+						Values:   []string{cur.Method, next.Method},
+					},
+				})
+			}
+		}
+
+		walkSiblingsForOracleManipulation(cur.Children, indicators)
+	}
+}
+
+// detectAdminCalls flags calls to functions that look owner/admin-gated.
+func detectAdminCalls(frames []callFrame) []Indicator {
+	var indicators []Indicator
+	for _, f := range frames {
+		if !containsAny(f.Method, adminFunctionKeywords) {
+			continue
+		}
+
+		indicators = append(indicators, Indicator{
+			Rule:     "admin-function-invocation",
+			Severity: SeverityMedium,
+			Evidence: Evidence{
+				Frame:    f.Method,
+				Contract: f.To,
+			},
+		})
+	}
+
+	return indicators
+}
+
+// eip1967AdminSlot and eip1967ImplementationSlot are the standardized
+// storage slots (EIP-1967) proxy contracts use to hold their admin and
+// implementation addresses. A write to either is a strong signal of a
+// privileged proxy-admin action, independent of what the call itself was
+// named - which lets this rule catch what detectAdminCalls' method-name
+// matching misses.
+const (
+	eip1967AdminSlot          = "b53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103"
+	eip1967ImplementationSlot = "360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"
+)
+
+// detectAdminStateChange flags a write to a well-known proxy admin or
+// implementation storage slot.
+func detectAdminStateChange(stateChanges []stateChangeEntry) []Indicator {
+	var indicators []Indicator
+	for _, sc := range stateChanges {
+		slot := strings.ToLower(strings.TrimPrefix(sc.Slot, "0x"))
+
+		var note string
+		switch slot {
+		case eip1967AdminSlot:
+			note = "proxy admin slot (EIP-1967) changed"
+		case eip1967ImplementationSlot:
+			note = "proxy implementation slot (EIP-1967) changed"
+		default:
+			continue
+		}
+
+		indicators = append(indicators, Indicator{
+			Rule:     "admin-function-invocation",
+			Severity: SeverityHigh,
+			Evidence: Evidence{
+				Contract: sc.Address,
+				Values:   []string{note},
+			},
+		})
+	}
+
+	return indicators
+}
+
+// detectDisproportionateOutflow flags a single address whose balance
+// decrease dwarfs every other outflow in the transaction, consistent
+// with funds being drained to one destination.
+func detectDisproportionateOutflow(balances []balanceEntry) []Indicator {
+	type outflow struct {
+		address string
+		amount  *big.Int
+	}
+
+	var outflows []outflow
+	total := new(big.Int)
+
+	for _, b := range balances {
+		before, ok1 := new(big.Int).SetString(b.Before, 10)
+		after, ok2 := new(big.Int).SetString(b.After, 10)
+		if !ok1 || !ok2 {
+			continue
+		}
+
+		delta := new(big.Int).Sub(before, after)
+		if delta.Sign() <= 0 {
+			continue // not an outflow
+		}
+
+		outflows = append(outflows, outflow{address: b.Address, amount: delta})
+		total.Add(total, delta)
+	}
+
+	if len(outflows) == 0 || total.Sign() == 0 {
+		return nil
+	}
+
+	var indicators []Indicator
+	threshold := new(big.Int).Mul(total, big.NewInt(75))
+	threshold.Div(threshold, big.NewInt(100))
+
+	for _, o := range outflows {
+		if o.amount.Cmp(threshold) < 0 {
+			continue
+		}
+
+		indicators = append(indicators, Indicator{
+			Rule:     "disproportionate-outflow",
+			Severity: SeverityHigh,
+			Evidence: Evidence{
+				Contract: o.address,
+				Values:   []string{fmt.Sprintf("%s of %s total outflow", o.amount.String(), total.String())},
+			},
+		})
+	}
+
+	return indicators
+}
+
+// containsAny reports whether s contains any of substrs, case-insensitively.
+func containsAny(s string, substrs []string) bool {
+	if s == "" {
+		return false
+	}
+	s = strings.ToLower(s)
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
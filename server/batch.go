@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultMaxConcurrency bounds how many batch items run at once when the
+// caller didn't configure --max-concurrency.
+const defaultMaxConcurrency = 8
+
+// BatchItem identifies a single transaction within a trace-batch or
+// overview-batch request.
+type BatchItem struct {
+	ChainID         string `json:"chainId"`
+	TransactionHash string `json:"transactionHash"`
+}
+
+// BatchResult is one item's outcome within a batch response.
+type BatchResult struct {
+	BatchItem
+	Data  json.RawMessage `json:"data,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// extractBatchItems parses the "items" argument shared by the batch tools.
+func extractBatchItems(request mcp.CallToolRequest) ([]BatchItem, error) {
+	raw, ok := request.GetArguments()["items"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("items must be an array")
+	}
+
+	items := make([]BatchItem, 0, len(raw))
+	for i, v := range raw {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("items[%d] must be an object", i)
+		}
+
+		chainId, _ := obj["chainId"].(string)
+		txHash, _ := obj["transactionHash"].(string)
+		if chainId == "" || txHash == "" {
+			return nil, fmt.Errorf("items[%d] must have chainId and transactionHash", i)
+		}
+
+		items = append(items, BatchItem{ChainID: chainId, TransactionHash: txHash})
+	}
+
+	return items, nil
+}
+
+// runBatch processes items with a worker pool bounded to s.maxConcurrency,
+// calling fn for each and collecting results in input order. A non-nil
+// error from fn becomes that item's Error field rather than failing the
+// whole batch, so one bad transaction hash doesn't lose the rest.
+func (s *Server) runBatch(ctx context.Context, items []BatchItem, fn func(ctx context.Context, item BatchItem) (json.RawMessage, error)) []BatchResult {
+	results := make([]BatchResult, len(items))
+	sem := make(chan struct{}, s.maxConcurrency)
+
+	var wg sync.WaitGroup
+	for i, item := range items {
+		wg.Add(1)
+		go func(i int, item BatchItem) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results[i] = BatchResult{BatchItem: item, Error: ctx.Err().Error()}
+				return
+			}
+			defer func() { <-sem }()
+
+			data, err := fn(ctx, item)
+			if err != nil {
+				results[i] = BatchResult{BatchItem: item, Error: err.Error()}
+				return
+			}
+			results[i] = BatchResult{BatchItem: item, Data: data}
+		}(i, item)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// traceBatchHandler handles the trace-batch tool requests
+func (s *Server) traceBatchHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	items, err := extractBatchItems(request)
+	if err != nil {
+		return nil, err
+	}
+
+	results := s.runBatch(ctx, items, func(ctx context.Context, item BatchItem) (json.RawMessage, error) {
+		chainId, err := strconv.Atoi(item.ChainID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chainId format: %v", err)
+		}
+
+		data, _, err := s.queryDataSources(ctx, "trace", chainId, item.TransactionHash, DataSource.Trace)
+		return data, err
+	})
+
+	return marshalBatchResults(results)
+}
+
+// overviewBatchHandler handles the overview-batch tool requests
+func (s *Server) overviewBatchHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	items, err := extractBatchItems(request)
+	if err != nil {
+		return nil, err
+	}
+
+	results := s.runBatch(ctx, items, func(ctx context.Context, item BatchItem) (json.RawMessage, error) {
+		chainId, err := strconv.Atoi(item.ChainID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chainId format: %v", err)
+		}
+
+		overview := s.transactionOverview(ctx, chainId, item.TransactionHash)
+		return json.Marshal(overview)
+	})
+
+	return marshalBatchResults(results)
+}
+
+// marshalBatchResults renders a batch's per-item results as the tool's
+// JSON response.
+func marshalBatchResults(results []BatchResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal batch results: %v", err)
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func newCallToolRequest(arguments any) mcp.CallToolRequest {
+	var req mcp.CallToolRequest
+	req.Params.Arguments = arguments
+	return req
+}
+
+func TestExtractBatchItems(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments any
+		want      []BatchItem
+		wantErr   bool
+	}{
+		{
+			name: "valid items",
+			arguments: map[string]any{
+				"items": []any{
+					map[string]any{"chainId": "1", "transactionHash": "0xabc"},
+					map[string]any{"chainId": "56", "transactionHash": "0xdef"},
+				},
+			},
+			want: []BatchItem{
+				{ChainID: "1", TransactionHash: "0xabc"},
+				{ChainID: "56", TransactionHash: "0xdef"},
+			},
+		},
+		{
+			name:      "items not an array",
+			arguments: map[string]any{"items": "not-an-array"},
+			wantErr:   true,
+		},
+		{
+			name: "item not an object",
+			arguments: map[string]any{
+				"items": []any{"not-an-object"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "item missing transactionHash",
+			arguments: map[string]any{
+				"items": []any{
+					map[string]any{"chainId": "1"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractBatchItems(newCallToolRequest(tt.arguments))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("extractBatchItems() error = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("extractBatchItems() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("extractBatchItems() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("extractBatchItems()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRunBatchPreservesOrderAndCapturesErrors(t *testing.T) {
+	s := &Server{maxConcurrency: 2}
+	items := []BatchItem{
+		{ChainID: "1", TransactionHash: "0xa"},
+		{ChainID: "1", TransactionHash: "0xb"},
+		{ChainID: "1", TransactionHash: "0xc"},
+	}
+
+	results := s.runBatch(context.Background(), items, func(ctx context.Context, item BatchItem) (json.RawMessage, error) {
+		if item.TransactionHash == "0xb" {
+			return nil, errors.New("boom")
+		}
+		return json.RawMessage(`"` + item.TransactionHash + `"`), nil
+	})
+
+	if len(results) != len(items) {
+		t.Fatalf("runBatch() returned %d results, want %d", len(results), len(items))
+	}
+	for i, item := range items {
+		if results[i].BatchItem != item {
+			t.Errorf("results[%d].BatchItem = %+v, want %+v", i, results[i].BatchItem, item)
+		}
+	}
+	if results[1].Error != "boom" {
+		t.Errorf("results[1].Error = %q, want %q", results[1].Error, "boom")
+	}
+	if string(results[0].Data) != `"0xa"` {
+		t.Errorf("results[0].Data = %s, want %q", results[0].Data, `"0xa"`)
+	}
+}
+
+func TestRunBatchRespectsMaxConcurrency(t *testing.T) {
+	s := &Server{maxConcurrency: 2}
+	items := make([]BatchItem, 6)
+	for i := range items {
+		items[i] = BatchItem{ChainID: "1", TransactionHash: "0x0"}
+	}
+
+	var inflight, maxInflight int32
+	s.runBatch(context.Background(), items, func(ctx context.Context, item BatchItem) (json.RawMessage, error) {
+		cur := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxInflight)
+			if cur <= max || atomic.CompareAndSwapInt32(&maxInflight, max, cur) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		return nil, nil
+	})
+
+	if maxInflight > int32(s.maxConcurrency) {
+		t.Errorf("observed %d concurrent calls, want at most %d", maxInflight, s.maxConcurrency)
+	}
+}
+
+func TestRunBatchStopsOnCancellation(t *testing.T) {
+	// A zero-buffer semaphore means the "acquire a slot" send can never
+	// succeed without a concurrent receiver, so with an already-cancelled
+	// context the <-ctx.Done() case is the only one that can ever fire.
+	s := &Server{maxConcurrency: 0}
+	items := []BatchItem{
+		{ChainID: "1", TransactionHash: "0xa"},
+		{ChainID: "1", TransactionHash: "0xb"},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := s.runBatch(ctx, items, func(ctx context.Context, item BatchItem) (json.RawMessage, error) {
+		t.Errorf("fn should not run once the context is already cancelled")
+		return nil, nil
+	})
+
+	for i, r := range results {
+		if r.Error == "" {
+			t.Errorf("results[%d].Error = %q, want a cancellation error", i, r.Error)
+		}
+	}
+}
+
+func TestMarshalBatchResults(t *testing.T) {
+	results := []BatchResult{
+		{BatchItem: BatchItem{ChainID: "1", TransactionHash: "0xa"}, Data: json.RawMessage(`{"ok":true}`)},
+		{BatchItem: BatchItem{ChainID: "1", TransactionHash: "0xb"}, Error: "boom"},
+	}
+
+	toolResult, err := marshalBatchResults(results)
+	if err != nil {
+		t.Fatalf("marshalBatchResults() error = %v", err)
+	}
+
+	text, ok := mcp.AsTextContent(toolResult.Content[0])
+	if !ok {
+		t.Fatalf("expected text content, got %T", toolResult.Content[0])
+	}
+
+	var got []BatchResult
+	if err := json.Unmarshal([]byte(text.Text), &got); err != nil {
+		t.Fatalf("failed to unmarshal result text: %v", err)
+	}
+	if len(got) != len(results) {
+		t.Fatalf("marshalBatchResults() produced %d results, want %d", len(got), len(results))
+	}
+	if got[1].Error != "boom" {
+		t.Errorf("got[1].Error = %q, want %q", got[1].Error, "boom")
+	}
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/phalcon-mcp/cache"
+)
+
+// newDefaultDataSources builds the ordered list of DataSources a Server
+// uses out of the box: the official API when a key is configured, the
+// cookie-scraping fallback, and the explorer source for anything neither
+// of those cover.
+func newDefaultDataSources() []DataSource {
+	var sources []DataSource
+
+	if apiKey := os.Getenv("PHALCON_API_KEY"); apiKey != "" {
+		sources = append(sources, NewBlocksecAPIDataSource(apiKey, os.Getenv("PHALCON_API_BASE_URL")))
+	}
+
+	if scrape, err := NewBlocksecScrapeDataSource(); err == nil {
+		sources = append(sources, scrape)
+	}
+
+	if explorerKey := os.Getenv("EXPLORER_API_KEY"); explorerKey != "" {
+		sources = append(sources, NewExplorerDataSource(explorerKey))
+	}
+
+	return sources
+}
+
+// DataSource is implemented by anything that can answer transaction
+// forensics queries for a given chain and transaction hash. The Server
+// holds an ordered list of DataSources and falls through to the next one
+// whenever the current one fails, so a single flaky backend doesn't take
+// the whole tool suite down with it.
+type DataSource interface {
+	// Name identifies the data source in logs and aggregated results.
+	Name() string
+	Trace(ctx context.Context, chainId int, txHash string) ([]byte, error)
+	Profile(ctx context.Context, chainId int, txHash string) ([]byte, error)
+	StateChange(ctx context.Context, chainId int, txHash string) ([]byte, error)
+	BalanceChange(ctx context.Context, chainId int, txHash string) ([]byte, error)
+	AddressLabel(ctx context.Context, chainId int, txHash string) ([]byte, error)
+}
+
+// dataSourceMethod selects which DataSource operation to invoke. Method
+// expressions such as DataSource.Trace satisfy this type directly.
+type dataSourceMethod func(ds DataSource, ctx context.Context, chainId int, txHash string) ([]byte, error)
+
+// queryDataSources tries method against each configured data source in
+// order, returning the first successful result. If every source fails,
+// the returned error combines all of their failures. toolName identifies
+// the calling tool (e.g. "trace") for cache-key purposes; a given
+// (toolName, chainId, txHash) result is cached forever once any source
+// succeeds, since a mined transaction never changes.
+func (s *Server) queryDataSources(ctx context.Context, toolName string, chainId int, txHash string, method dataSourceMethod) ([]byte, string, error) {
+	key := cache.Key(toolName, strconv.Itoa(chainId), txHash)
+
+	if s.cache != nil {
+		if data, ok := s.cache.Get(key); ok {
+			return data, "cache", nil
+		}
+	}
+
+	if len(s.dataSources) == 0 {
+		return nil, "", fmt.Errorf("no data sources configured")
+	}
+
+	var errs []string
+	for _, ds := range s.dataSources {
+		data, err := method(ds, ctx, chainId, txHash)
+		if err == nil {
+			if s.cache != nil {
+				_ = s.cache.Set(key, data, 0)
+			}
+			return data, ds.Name(), nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", ds.Name(), err))
+	}
+
+	return nil, "", fmt.Errorf("all data sources failed: %s", strings.Join(errs, "; "))
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// defaultPhalconAPIBaseURL is the official Phalcon/BlockSec REST API.
+const defaultPhalconAPIBaseURL = "https://openapi.blocksec.com/api/v1"
+
+// BlocksecAPIDataSource talks to the official Phalcon/BlockSec REST API
+// using an API key, rather than scraping the web explorer. It's the
+// preferred data source whenever an API key is configured.
+type BlocksecAPIDataSource struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+// NewBlocksecAPIDataSource creates a BlocksecAPIDataSource authenticated
+// with apiKey. baseURL overrides the default API host when non-empty,
+// which is mainly useful for testing against a mock server.
+func NewBlocksecAPIDataSource(apiKey, baseURL string) *BlocksecAPIDataSource {
+	if baseURL == "" {
+		baseURL = defaultPhalconAPIBaseURL
+	}
+
+	return &BlocksecAPIDataSource{
+		apiKey:  apiKey,
+		baseURL: baseURL,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name identifies this data source in aggregated results.
+func (d *BlocksecAPIDataSource) Name() string {
+	return "blocksec-api"
+}
+
+func (d *BlocksecAPIDataSource) Trace(ctx context.Context, chainId int, txHash string) ([]byte, error) {
+	return d.call(ctx, "trace", chainId, txHash)
+}
+
+func (d *BlocksecAPIDataSource) Profile(ctx context.Context, chainId int, txHash string) ([]byte, error) {
+	return d.call(ctx, "profile", chainId, txHash)
+}
+
+func (d *BlocksecAPIDataSource) StateChange(ctx context.Context, chainId int, txHash string) ([]byte, error) {
+	return d.call(ctx, "state-change", chainId, txHash)
+}
+
+func (d *BlocksecAPIDataSource) BalanceChange(ctx context.Context, chainId int, txHash string) ([]byte, error) {
+	return d.call(ctx, "balance-change", chainId, txHash)
+}
+
+func (d *BlocksecAPIDataSource) AddressLabel(ctx context.Context, chainId int, txHash string) ([]byte, error) {
+	return d.call(ctx, "address-label", chainId, txHash)
+}
+
+// call issues an authenticated request against the Phalcon REST API.
+func (d *BlocksecAPIDataSource) call(ctx context.Context, endpoint string, chainId int, txHash string) ([]byte, error) {
+	reqBody := BlocksecTraceRequest{
+		ChainID: chainId,
+		TxnHash: txHash,
+		Blocked: false,
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %v", err)
+	}
+
+	apiURL := fmt.Sprintf("%s/onchain/tx/%s", d.baseURL, endpoint)
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("API-KEY", d.apiKey)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to Phalcon API: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Phalcon API returned non-200 status code: %d - %s", resp.StatusCode, string(respBody))
+	}
+
+	return respBody, nil
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// explorerAPIBases maps a chain ID to its Etherscan-family block explorer
+// API base URL, for chains the BlockSec data sources don't cover.
+var explorerAPIBases = map[int]string{
+	1:     "https://api.etherscan.io/api",
+	56:    "https://api.bscscan.com/api",
+	137:   "https://api.polygonscan.com/api",
+	42161: "https://api.arbiscan.io/api",
+	10:    "https://api-optimistic.etherscan.io/api",
+}
+
+// ExplorerDataSource answers queries using Etherscan-style block explorer
+// APIs. It only understands internal-transaction traces: explorers don't
+// expose a profile/gas-breakdown endpoint, nor state diffs or
+// balance-change breakdowns, the way Phalcon does. It's meant as a
+// last-resort source for chains Phalcon hasn't indexed yet, not a full
+// replacement.
+type ExplorerDataSource struct {
+	apiKey string
+	client *http.Client
+}
+
+// NewExplorerDataSource creates an ExplorerDataSource authenticated with
+// apiKey, used across all explorers in explorerAPIBases.
+func NewExplorerDataSource(apiKey string) *ExplorerDataSource {
+	return &ExplorerDataSource{
+		apiKey: apiKey,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name identifies this data source in aggregated results.
+func (d *ExplorerDataSource) Name() string {
+	return "explorer"
+}
+
+func (d *ExplorerDataSource) Trace(ctx context.Context, chainId int, txHash string) ([]byte, error) {
+	base, ok := explorerAPIBases[chainId]
+	if !ok {
+		return nil, fmt.Errorf("no block explorer configured for chain %d", chainId)
+	}
+
+	return d.get(ctx, explorerURL(base, map[string]string{
+		"module": "account",
+		"action": "txlistinternal",
+		"txhash": txHash,
+		"apikey": d.apiKey,
+	}))
+}
+
+func (d *ExplorerDataSource) Profile(ctx context.Context, chainId int, txHash string) ([]byte, error) {
+	return nil, fmt.Errorf("profiles are not available from block explorer APIs")
+}
+
+func (d *ExplorerDataSource) StateChange(ctx context.Context, chainId int, txHash string) ([]byte, error) {
+	return nil, fmt.Errorf("state changes are not available from block explorer APIs")
+}
+
+func (d *ExplorerDataSource) BalanceChange(ctx context.Context, chainId int, txHash string) ([]byte, error) {
+	return nil, fmt.Errorf("balance changes are not available from block explorer APIs")
+}
+
+func (d *ExplorerDataSource) AddressLabel(ctx context.Context, chainId int, txHash string) ([]byte, error) {
+	return nil, fmt.Errorf("address labels are not available from block explorer APIs")
+}
+
+// explorerURL builds base with params as a properly escaped query string,
+// so a value like txHash can't inject or override another parameter.
+func explorerURL(base string, params map[string]string) string {
+	values := url.Values{}
+	for k, v := range params {
+		values.Set(k, v)
+	}
+	return base + "?" + values.Encode()
+}
+
+// get issues a GET request and returns the raw response body if the
+// explorer reports success.
+func (d *ExplorerDataSource) get(ctx context.Context, reqURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create explorer request: %v", err)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to block explorer: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("block explorer returned non-200 status code: %d - %s", resp.StatusCode, string(respBody))
+	}
+
+	var envelope struct {
+		Status  string          `json:"status"`
+		Message string          `json:"message"`
+		Result  json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(respBody, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal explorer response: %v", err)
+	}
+	if envelope.Status == "0" {
+		return nil, fmt.Errorf("block explorer error: %s", envelope.Message)
+	}
+
+	return envelope.Result, nil
+}
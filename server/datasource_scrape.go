@@ -0,0 +1,198 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"sync"
+)
+
+// BlocksecScrapeDataSource talks to app.blocksec.com the way a logged-out
+// browser session would: it primes a cookie jar against the explorer page
+// and then calls the same internal API the web UI uses. It has no API key
+// requirement, which makes it a useful fallback, but it's fragile and can
+// be blocked at any time since it isn't an official integration.
+//
+// Cookies are primed once and shared across every call this data source
+// makes, rather than re-primed per transaction, so a trace-batch call
+// over many transactions doesn't pay the priming round trip N times.
+type BlocksecScrapeDataSource struct {
+	client *http.Client
+
+	primeOnce sync.Once
+	primeErr  error
+}
+
+// NewBlocksecScrapeDataSource creates a BlocksecScrapeDataSource backed by
+// its own cookie-jar-enabled HTTP client.
+func NewBlocksecScrapeDataSource() (*BlocksecScrapeDataSource, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cookie jar: %v", err)
+	}
+
+	client := &http.Client{
+		Jar: jar,
+		// Don't follow redirects
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	return &BlocksecScrapeDataSource{client: client}, nil
+}
+
+// Name identifies this data source in aggregated results.
+func (d *BlocksecScrapeDataSource) Name() string {
+	return "blocksec-scrape"
+}
+
+func (d *BlocksecScrapeDataSource) Trace(ctx context.Context, chainId int, txHash string) ([]byte, error) {
+	return d.call(ctx, "trace", chainId, txHash)
+}
+
+func (d *BlocksecScrapeDataSource) Profile(ctx context.Context, chainId int, txHash string) ([]byte, error) {
+	return d.call(ctx, "profile", chainId, txHash)
+}
+
+func (d *BlocksecScrapeDataSource) StateChange(ctx context.Context, chainId int, txHash string) ([]byte, error) {
+	return d.call(ctx, "state-change", chainId, txHash)
+}
+
+func (d *BlocksecScrapeDataSource) BalanceChange(ctx context.Context, chainId int, txHash string) ([]byte, error) {
+	return d.call(ctx, "balance-change", chainId, txHash)
+}
+
+func (d *BlocksecScrapeDataSource) AddressLabel(ctx context.Context, chainId int, txHash string) ([]byte, error) {
+	return d.call(ctx, "address-label", chainId, txHash)
+}
+
+// call primes cookies on first use and then hits endpoint, reusing the
+// same primed client for every subsequent call.
+func (d *BlocksecScrapeDataSource) call(ctx context.Context, endpoint string, chainId int, txHash string) ([]byte, error) {
+	d.primeOnce.Do(func() {
+		d.primeErr = fetchBlocksecCookies(ctx, d.client)
+	})
+	if d.primeErr != nil {
+		return nil, d.primeErr
+	}
+
+	return callBlocksecAPI(ctx, d.client, endpoint, chainId, txHash)
+}
+
+// BlocksecTraceRequest represents the request payload for BlockSec API
+type BlocksecTraceRequest struct {
+	ChainID int    `json:"chainID"`
+	TxnHash string `json:"txnHash"`
+	Blocked bool   `json:"blocked"`
+}
+
+// fetchBlocksecCookies visits the main site to get cookies with retries
+func fetchBlocksecCookies(ctx context.Context, client *http.Client) error {
+	maxRetries := 3
+	var lastErr error
+	mainPageURL := "https://app.blocksec.com/explorer"
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+
+		req, err := http.NewRequestWithContext(ctx, "GET", mainPageURL, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request to main page: %v", err)
+		}
+
+		// Set browser-like headers
+		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
+		req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8")
+		req.Header.Set("Accept-Language", "en-US,en;q=0.5")
+		req.Header.Set("Connection", "keep-alive")
+		req.Header.Set("Upgrade-Insecure-Requests", "1")
+
+		// Get main page to retrieve cookies
+		mainResp, err := client.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to send request to main site: %v", err)
+			continue
+		}
+
+		// Check for successful response
+		if mainResp.StatusCode != http.StatusOK {
+			mainResp.Body.Close()
+			lastErr = fmt.Errorf("main site returned non-200 status code: %d", mainResp.StatusCode)
+			continue
+		}
+
+		mainResp.Body.Close() // We don't need the body
+		return nil            // Success
+	}
+
+	// If we've tried maxRetries times and still failed, return the last error
+	return fmt.Errorf("failed to fetch cookies after %d attempts: %v", maxRetries, lastErr)
+}
+
+// callBlocksecAPI makes an API call to the BlockSec API with retries
+func callBlocksecAPI(ctx context.Context, client *http.Client, endpoint string, chainId int, txHash string) ([]byte, error) {
+	// Configure retries
+	maxRetries := 3
+	var lastErr error
+	var respBody []byte
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		// Prepare the API request with cookies
+		reqBody := BlocksecTraceRequest{
+			ChainID: chainId,
+			TxnHash: txHash,
+			Blocked: false,
+		}
+
+		// Convert request to JSON
+		jsonData, err := json.Marshal(reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %v", err)
+		}
+
+		// Create a new request for the API
+		apiURL := fmt.Sprintf("https://app.blocksec.com/api/v1/onchain/tx/%s", endpoint)
+		apiReq, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonData))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create API request: %v", err)
+		}
+
+		// Set headers to mimic a browser for the API request
+		apiReq.Header.Set("Content-Type", "application/json")
+		apiReq.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
+		apiReq.Header.Set("Accept", "application/json, text/plain, */*")
+		apiReq.Header.Set("Origin", "https://app.blocksec.com")
+		apiReq.Header.Set("Referer", "https://app.blocksec.com/explorer")
+
+		// Send the API request
+		resp, err := client.Do(apiReq)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to send request to BlockSec API: %v", err)
+			continue // Try again
+		}
+
+		// Read response body
+		respBody, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if err != nil {
+			lastErr = fmt.Errorf("failed to read response: %v", err)
+			continue // Try again
+		}
+
+		// Check if the request was successful
+		if resp.StatusCode == http.StatusOK {
+			return respBody, nil // Success, return the response
+		}
+
+		// If we got here, the request failed with a non-200 status code
+		lastErr = fmt.Errorf("BlockSec API returned non-200 status code: %d - %s", resp.StatusCode, string(respBody))
+	}
+
+	// If we've tried maxRetries times and still failed, return the last error
+	return nil, fmt.Errorf("failed after %d attempts: %v", maxRetries, lastErr)
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/phalcon-mcp/exploit"
+)
+
+// detectExploitHandler handles the detect-exploit tool requests by
+// gathering trace, state-change, and balance-change data for a
+// transaction and running the exploit rule engine over it.
+func (s *Server) detectExploitHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chainId, txHash, err := extractRequestParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	trace, _, err := s.queryDataSources(ctx, "trace", chainId, txHash, DataSource.Trace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch trace: %v", err)
+	}
+
+	stateChange, _, err := s.queryDataSources(ctx, "state-change", chainId, txHash, DataSource.StateChange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch state change: %v", err)
+	}
+
+	balanceChange, _, err := s.queryDataSources(ctx, "balance-change", chainId, txHash, DataSource.BalanceChange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch balance change: %v", err)
+	}
+
+	report, err := exploit.Analyze(trace, stateChange, balanceChange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze transaction: %v", err)
+	}
+
+	resultJSON, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal exploit report: %v", err)
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
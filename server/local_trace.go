@@ -0,0 +1,59 @@
+package server
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// extractRPCURL returns the optional "rpcUrl" argument from request, or
+// the empty string when the caller didn't supply one.
+func extractRPCURL(request mcp.CallToolRequest) string {
+	rpcURL, _ := request.GetArguments()["rpcUrl"].(string)
+	return rpcURL
+}
+
+// localTraceHandler handles the local-trace tool requests
+func (s *Server) localTraceHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chainId, txHash, err := extractRequestParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := s.tracer.Trace(ctx, chainId, txHash, "", extractRPCURL(request))
+	if err != nil {
+		return nil, err
+	}
+
+	return formatJSONResponse(respBody)
+}
+
+// localStateDiffHandler handles the local-state-diff tool requests
+func (s *Server) localStateDiffHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chainId, txHash, err := extractRequestParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := s.tracer.StateDiff(ctx, chainId, txHash, extractRPCURL(request))
+	if err != nil {
+		return nil, err
+	}
+
+	return formatJSONResponse(respBody)
+}
+
+// localBalanceChangeHandler handles the local-balance-change tool requests
+func (s *Server) localBalanceChangeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chainId, txHash, err := extractRequestParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := s.tracer.BalanceChange(ctx, chainId, txHash, extractRPCURL(request))
+	if err != nil {
+		return nil, err
+	}
+
+	return formatJSONResponse(respBody)
+}
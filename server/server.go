@@ -1,35 +1,91 @@
 package server
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"net/http/cookiejar"
+	"os"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	mcpserver "github.com/mark3labs/mcp-go/server"
+	"github.com/mark3labs/phalcon-mcp/cache"
+	"github.com/mark3labs/phalcon-mcp/tracer"
 )
 
+// chainListCacheKey is the cache key under which the chainlist.org chain
+// list is stored.
+const chainListCacheKey = "chainlist"
+
+// chainListTTL bounds how long a cached chain list is reused before
+// being re-fetched.
+const chainListTTL = 24 * time.Hour
+
+// rpcConfigEnvVar names the environment variable pointing at the JSON
+// file mapping chain IDs to JSON-RPC endpoints for the local tracer.
+const rpcConfigEnvVar = "PHALCON_MCP_RPC_CONFIG"
+
+// loadRPCEndpoints loads the chainId -> rpcUrl mapping used by the local
+// tracer tools from the file named by PHALCON_MCP_RPC_CONFIG, if set.
+// Callers can still supply a per-call "rpcUrl" argument when no mapping
+// is configured.
+func loadRPCEndpoints() map[int]string {
+	path := os.Getenv(rpcConfigEnvVar)
+	if path == "" {
+		return nil
+	}
+
+	endpoints, err := tracer.LoadConfig(path)
+	if err != nil {
+		fmt.Printf("warning: failed to load RPC config: %v\n", err)
+		return nil
+	}
+
+	return endpoints
+}
+
 // Server represents the Phalcon MCP server
 type Server struct {
-	mcpServer *mcpserver.MCPServer
-	version   string
+	mcpServer      *mcpserver.MCPServer
+	version        string
+	dataSources    []DataSource
+	tracer         *tracer.Tracer
+	cache          *cache.Store
+	maxConcurrency int
 }
 
-// NewServer creates a new Phalcon MCP server
-func NewServer(version string) *Server {
+// NewServer creates a new Phalcon MCP server. cacheDir enables the
+// on-disk response cache when non-empty; pass "" to disable caching.
+// maxConcurrency bounds how many items a trace-batch/overview-batch call
+// processes at once; a value <= 0 falls back to defaultMaxConcurrency.
+func NewServer(version, cacheDir string, maxConcurrency int) *Server {
+	if maxConcurrency <= 0 {
+		maxConcurrency = defaultMaxConcurrency
+	}
+
 	s := &Server{
 		mcpServer: mcpserver.NewMCPServer(
 			"Phalcon MCP",
 			version,
 		),
-		version: version,
+		version:        version,
+		dataSources:    newDefaultDataSources(),
+		tracer:         tracer.New(loadRPCEndpoints()),
+		maxConcurrency: maxConcurrency,
+	}
+
+	if cacheDir != "" {
+		store, err := cache.New(cacheDir)
+		if err != nil {
+			fmt.Printf("warning: failed to initialize cache, continuing without it: %v\n", err)
+		} else {
+			s.cache = store
+		}
 	}
 
 	// Add trace tool
@@ -119,6 +175,85 @@ func NewServer(version string) *Server {
 		),
 	)
 
+	// Add local-trace tool
+	localTraceTool := mcp.NewTool("local-trace",
+		mcp.WithDescription("Trace a transaction by calling debug_traceTransaction directly against a JSON-RPC endpoint, without relying on Phalcon."),
+		mcp.WithString("chainId",
+			mcp.Required(),
+			mcp.Description("ID of the blockchain"),
+		),
+		mcp.WithString("transactionHash",
+			mcp.Required(),
+			mcp.Description("Hash of the transaction to trace"),
+		),
+		mcp.WithString("rpcUrl",
+			mcp.Description("JSON-RPC endpoint to use, overriding the configured mapping for chainId"),
+		),
+	)
+
+	// Add local-state-diff tool
+	localStateDiffTool := mcp.NewTool("local-state-diff",
+		mcp.WithDescription("Get the before/after account state for a transaction by calling debug_traceTransaction with the prestateTracer directly against a JSON-RPC endpoint."),
+		mcp.WithString("chainId",
+			mcp.Required(),
+			mcp.Description("ID of the blockchain"),
+		),
+		mcp.WithString("transactionHash",
+			mcp.Required(),
+			mcp.Description("Hash of the transaction to get the state diff for"),
+		),
+		mcp.WithString("rpcUrl",
+			mcp.Description("JSON-RPC endpoint to use, overriding the configured mapping for chainId"),
+		),
+	)
+
+	// Add local-balance-change tool
+	localBalanceChangeTool := mcp.NewTool("local-balance-change",
+		mcp.WithDescription("Get per-account native balance changes for a transaction, derived from a prestateTracer diff against a JSON-RPC endpoint."),
+		mcp.WithString("chainId",
+			mcp.Required(),
+			mcp.Description("ID of the blockchain"),
+		),
+		mcp.WithString("transactionHash",
+			mcp.Required(),
+			mcp.Description("Hash of the transaction to get balance changes for"),
+		),
+		mcp.WithString("rpcUrl",
+			mcp.Description("JSON-RPC endpoint to use, overriding the configured mapping for chainId"),
+		),
+	)
+
+	// Add detect-exploit tool
+	detectExploitTool := mcp.NewTool("detect-exploit",
+		mcp.WithDescription("Analyze a transaction's trace, state changes, and balance changes for indicators of a known exploit pattern (reentrancy, flash-loan usage, oracle manipulation, admin-only calls, disproportionate outflows)."),
+		mcp.WithString("chainId",
+			mcp.Required(),
+			mcp.Description("ID of the blockchain"),
+		),
+		mcp.WithString("transactionHash",
+			mcp.Required(),
+			mcp.Description("Hash of the transaction to analyze"),
+		),
+	)
+
+	// Add trace-batch tool
+	traceBatchTool := mcp.NewTool("trace-batch",
+		mcp.WithDescription("Trace multiple transactions in one call using a bounded worker pool, returning per-item results so some transactions can fail without losing the rest."),
+		mcp.WithArray("items",
+			mcp.Required(),
+			mcp.Description("Array of {chainId, transactionHash} pairs to trace"),
+		),
+	)
+
+	// Add overview-batch tool
+	overviewBatchTool := mcp.NewTool("overview-batch",
+		mcp.WithDescription("Get a transaction-overview for multiple transactions in one call using a bounded worker pool, returning per-item results so some transactions can fail without losing the rest."),
+		mcp.WithArray("items",
+			mcp.Required(),
+			mcp.Description("Array of {chainId, transactionHash} pairs to analyze"),
+		),
+	)
+
 	// Add tool handlers
 	s.mcpServer.AddTool(traceTool, s.traceHandler)
 	s.mcpServer.AddTool(profileTool, s.profileHandler)
@@ -127,6 +262,12 @@ func NewServer(version string) *Server {
 	s.mcpServer.AddTool(stateChangeTool, s.stateChangeHandler)
 	s.mcpServer.AddTool(transactionOverviewTool, s.transactionOverviewHandler)
 	s.mcpServer.AddTool(getChainIdTool, s.getChainIdByNameHandler)
+	s.mcpServer.AddTool(localTraceTool, s.localTraceHandler)
+	s.mcpServer.AddTool(localStateDiffTool, s.localStateDiffHandler)
+	s.mcpServer.AddTool(localBalanceChangeTool, s.localBalanceChangeHandler)
+	s.mcpServer.AddTool(detectExploitTool, s.detectExploitHandler)
+	s.mcpServer.AddTool(traceBatchTool, s.traceBatchHandler)
+	s.mcpServer.AddTool(overviewBatchTool, s.overviewBatchHandler)
 
 	return s
 }
@@ -136,16 +277,35 @@ func (s *Server) ServeStdio() error {
 	return mcpserver.ServeStdio(s.mcpServer)
 }
 
-// BlocksecTraceRequest represents the request payload for BlockSec API
-type BlocksecTraceRequest struct {
-	ChainID int    `json:"chainID"`
-	TxnHash string `json:"txnHash"`
-	Blocked bool   `json:"blocked"`
+// ServeHTTP starts the MCP server using the Streamable HTTP transport,
+// listening on addr (e.g. ":8080").
+func (s *Server) ServeHTTP(addr string) error {
+	httpServer := mcpserver.NewStreamableHTTPServer(s.mcpServer)
+	return httpServer.Start(addr)
+}
+
+// ServeSSE starts the MCP server using the HTTP+SSE transport,
+// listening on addr (e.g. ":8080").
+func (s *Server) ServeSSE(addr string) error {
+	sseServer := mcpserver.NewSSEServer(s.mcpServer)
+	return sseServer.Start(addr)
+}
+
+// GetMCPServer returns the underlying mcp-go server, for callers that need
+// to wire up a transport this package doesn't wrap directly (e.g. an
+// in-process client).
+func (s *Server) GetMCPServer() *mcpserver.MCPServer {
+	return s.mcpServer
+}
+
+// GetVersion returns the server's version string.
+func (s *Server) GetVersion() string {
+	return s.version
 }
 
 // extractRequestParams extracts and validates chainId and transactionHash from the request
 func extractRequestParams(request mcp.CallToolRequest) (int, string, error) {
-	chainIdStr, ok := request.Params.Arguments["chainId"].(string)
+	chainIdStr, ok := request.GetArguments()["chainId"].(string)
 	if !ok {
 		return 0, "", fmt.Errorf("chainId must be a string")
 	}
@@ -156,7 +316,7 @@ func extractRequestParams(request mcp.CallToolRequest) (int, string, error) {
 		return 0, "", fmt.Errorf("invalid chainId format: %v", err)
 	}
 
-	txHash, ok := request.Params.Arguments["transactionHash"].(string)
+	txHash, ok := request.GetArguments()["transactionHash"].(string)
 	if !ok {
 		return 0, "", fmt.Errorf("transactionHash must be a string")
 	}
@@ -164,196 +324,92 @@ func extractRequestParams(request mcp.CallToolRequest) (int, string, error) {
 	return chainId, txHash, nil
 }
 
-// createHTTPClient creates an HTTP client with a cookie jar and browser-like headers
-func createHTTPClient() (*http.Client, error) {
-	// Create a cookiejar to store cookies
-	jar, err := cookiejar.New(nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create cookie jar: %v", err)
-	}
-
-	// Create HTTP client with the cookiejar
-	client := &http.Client{
-		Jar: jar,
-		// Don't follow redirects
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return http.ErrUseLastResponse
-		},
-	}
-
-	return client, nil
-}
-
-// fetchBlocksecCookies visits the main site to get cookies with retries
-func fetchBlocksecCookies(client *http.Client) error {
-	maxRetries := 3
-	var lastErr error
-	mainPageURL := "https://app.blocksec.com/explorer"
-
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-
-		req, err := http.NewRequest("GET", mainPageURL, nil)
-		if err != nil {
-			return fmt.Errorf("failed to create request to main page: %v", err)
-		}
-
-		// Set browser-like headers
-		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
-		req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8")
-		req.Header.Set("Accept-Language", "en-US,en;q=0.5")
-		req.Header.Set("Connection", "keep-alive")
-		req.Header.Set("Upgrade-Insecure-Requests", "1")
-
-		// Get main page to retrieve cookies
-		mainResp, err := client.Do(req)
-		if err != nil {
-			lastErr = fmt.Errorf("failed to send request to main site: %v", err)
-			continue
-		}
-
-		// Check for successful response
-		if mainResp.StatusCode != http.StatusOK {
-			mainResp.Body.Close()
-			lastErr = fmt.Errorf("main site returned non-200 status code: %d", mainResp.StatusCode)
-			continue
-		}
-
-		mainResp.Body.Close() // We don't need the body
-		return nil            // Success
-	}
-
-	// If we've tried maxRetries times and still failed, return the last error
-	return fmt.Errorf("failed to fetch cookies after %d attempts: %v", maxRetries, lastErr)
-}
-
-// callBlocksecAPI makes an API call to the BlockSec API with retries
-func callBlocksecAPI(client *http.Client, endpoint string, chainId int, txHash string) ([]byte, error) {
-	// Configure retries
-	maxRetries := 3
-	var lastErr error
-	var respBody []byte
-
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		// Prepare the API request with cookies
-		reqBody := BlocksecTraceRequest{
-			ChainID: chainId,
-			TxnHash: txHash,
-			Blocked: false,
-		}
-
-		// Convert request to JSON
-		jsonData, err := json.Marshal(reqBody)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request: %v", err)
-		}
-
-		// Create a new request for the API
-		apiURL := fmt.Sprintf("https://app.blocksec.com/api/v1/onchain/tx/%s", endpoint)
-		apiReq, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(jsonData))
-		if err != nil {
-			return nil, fmt.Errorf("failed to create API request: %v", err)
-		}
-
-		// Set headers to mimic a browser for the API request
-		apiReq.Header.Set("Content-Type", "application/json")
-		apiReq.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
-		apiReq.Header.Set("Accept", "application/json, text/plain, */*")
-		apiReq.Header.Set("Origin", "https://app.blocksec.com")
-		apiReq.Header.Set("Referer", "https://app.blocksec.com/explorer")
-
-		// Send the API request
-		resp, err := client.Do(apiReq)
-		if err != nil {
-			lastErr = fmt.Errorf("failed to send request to BlockSec API: %v", err)
-			continue // Try again
-		}
-
-		// Read response body
-		respBody, err = io.ReadAll(resp.Body)
-		resp.Body.Close()
-
-		if err != nil {
-			lastErr = fmt.Errorf("failed to read response: %v", err)
-			continue // Try again
-		}
-
-		// Check if the request was successful
-		if resp.StatusCode == http.StatusOK {
-			return respBody, nil // Success, return the response
-		}
-
-		// If we got here, the request failed with a non-200 status code
-		lastErr = fmt.Errorf("BlockSec API returned non-200 status code: %d - %s", resp.StatusCode, string(respBody))
-	}
-
-	// If we've tried maxRetries times and still failed, return the last error
-	return nil, fmt.Errorf("failed after %d attempts: %v", maxRetries, lastErr)
-}
-
 // formatJSONResponse formats the response as compact JSON
 func formatJSONResponse(respBody []byte) (*mcp.CallToolResult, error) {
 	// Return the raw JSON without indentation
 	return mcp.NewToolResultText(string(respBody)), nil
 }
 
-// handleBlocksecRequest handles all BlockSec API requests using shared code
-func (s *Server) handleBlocksecRequest(ctx context.Context, request mcp.CallToolRequest, endpoint string) (*mcp.CallToolResult, error) {
-	// Extract and validate parameters
+// traceHandler handles the trace tool requests
+func (s *Server) traceHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	chainId, txHash, err := extractRequestParams(request)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create HTTP client
-	client, err := createHTTPClient()
+	respBody, _, err := s.queryDataSources(ctx, "trace", chainId, txHash, DataSource.Trace)
 	if err != nil {
 		return nil, err
 	}
 
-	// Fetch cookies
-	if err := fetchBlocksecCookies(client); err != nil {
+	return formatJSONResponse(respBody)
+}
+
+// profileHandler handles the profile tool requests
+func (s *Server) profileHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chainId, txHash, err := extractRequestParams(request)
+	if err != nil {
 		return nil, err
 	}
 
-	// Call the API
-	respBody, err := callBlocksecAPI(client, endpoint, chainId, txHash)
+	respBody, _, err := s.queryDataSources(ctx, "profile", chainId, txHash, DataSource.Profile)
 	if err != nil {
 		return nil, err
 	}
 
-	// Format and return the response
 	return formatJSONResponse(respBody)
 }
 
-// traceHandler handles the trace tool requests
-func (s *Server) traceHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	return s.handleBlocksecRequest(ctx, request, "trace")
-}
-
-// profileHandler handles the profile tool requests
-func (s *Server) profileHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	return s.handleBlocksecRequest(ctx, request, "profile")
-}
-
 // addressLabelHandler handles the address-label tool requests
 func (s *Server) addressLabelHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	return s.handleBlocksecRequest(ctx, request, "address-label")
+	chainId, txHash, err := extractRequestParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, _, err := s.queryDataSources(ctx, "address-label", chainId, txHash, DataSource.AddressLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	return formatJSONResponse(respBody)
 }
 
 // balanceChangeHandler handles the balance-change tool requests
 func (s *Server) balanceChangeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	return s.handleBlocksecRequest(ctx, request, "balance-change")
+	chainId, txHash, err := extractRequestParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, _, err := s.queryDataSources(ctx, "balance-change", chainId, txHash, DataSource.BalanceChange)
+	if err != nil {
+		return nil, err
+	}
+
+	return formatJSONResponse(respBody)
 }
 
 // stateChangeHandler handles the state-change tool requests
 func (s *Server) stateChangeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	return s.handleBlocksecRequest(ctx, request, "state-change")
+	chainId, txHash, err := extractRequestParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, _, err := s.queryDataSources(ctx, "state-change", chainId, txHash, DataSource.StateChange)
+	if err != nil {
+		return nil, err
+	}
+
+	return formatJSONResponse(respBody)
 }
 
 // Result represents a single data source result with success/error status
 type Result struct {
 	Name    string          `json:"name"`
 	Success bool            `json:"success"`
+	Source  string          `json:"source,omitempty"`
 	Data    json.RawMessage `json:"data,omitempty"`
 	Error   string          `json:"error,omitempty"`
 }
@@ -371,37 +427,25 @@ type ChainData struct {
 	ChainId   uint64 `json:"chainId"`
 }
 
-// transactionOverviewHandler handles transaction-overview requests by calling all other handlers in parallel
-func (s *Server) transactionOverviewHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	// Define the endpoints to query
-	endpoints := []struct {
-		name     string
-		endpoint string
-	}{
-		{"trace", "trace"},
-		{"profile", "profile"},
-		{"address_label", "address-label"},
-		{"balance_change", "balance-change"},
-		{"state_change", "state-change"},
-	}
-
-	// Extract and validate parameters once
-	chainId, txHash, err := extractRequestParams(request)
-	if err != nil {
-		return nil, err
-	}
-
-	// Create HTTP client
-	client, err := createHTTPClient()
-	if err != nil {
-		return nil, err
-	}
-
-	// Fetch cookies once
-	if err := fetchBlocksecCookies(client); err != nil {
-		return nil, err
-	}
+// overviewTools lists the tools transaction-overview and overview-batch
+// aggregate. toolName matches the cache key used by the dedicated
+// handler for the same data, so an overview call and a standalone
+// trace/profile/etc. call share cache entries.
+var overviewTools = []struct {
+	name     string
+	toolName string
+	method   dataSourceMethod
+}{
+	{"trace", "trace", DataSource.Trace},
+	{"profile", "profile", DataSource.Profile},
+	{"address_label", "address-label", DataSource.AddressLabel},
+	{"balance_change", "balance-change", DataSource.BalanceChange},
+	{"state_change", "state-change", DataSource.StateChange},
+}
 
+// transactionOverview aggregates all overviewTools for a single
+// transaction in parallel.
+func (s *Server) transactionOverview(ctx context.Context, chainId int, txHash string) OverviewResult {
 	// Create a wait group to synchronize goroutines
 	var wg sync.WaitGroup
 	// Create a mutex to protect the results map
@@ -411,15 +455,14 @@ func (s *Server) transactionOverviewHandler(ctx context.Context, request mcp.Cal
 		Results: make(map[string]Result),
 	}
 
-	// Process each endpoint in parallel
-	for _, e := range endpoints {
+	// Process each tool in parallel, aggregating across data sources
+	for _, t := range overviewTools {
 		wg.Add(1)
-		// Create a closure to capture the current endpoint
-		go func(name, endpoint string) {
+		// Create a closure to capture the current tool
+		go func(name, toolName string, method dataSourceMethod) {
 			defer wg.Done()
 
-			// Call the API
-			respBody, err := callBlocksecAPI(client, endpoint, chainId, txHash)
+			respBody, source, err := s.queryDataSources(ctx, toolName, chainId, txHash, method)
 
 			// Store the result
 			mu.Lock()
@@ -438,15 +481,27 @@ func (s *Server) transactionOverviewHandler(ctx context.Context, request mcp.Cal
 			overviewResult.Results[name] = Result{
 				Name:    name,
 				Success: true,
+				Source:  source,
 				Data:    respBody,
 			}
-		}(e.name, e.endpoint)
+		}(t.name, t.toolName, t.method)
 	}
 
 	// Wait for all requests to complete
 	wg.Wait()
 
-	// Convert the overview result to JSON
+	return overviewResult
+}
+
+// transactionOverviewHandler handles transaction-overview requests by querying every overviewTools entry in parallel
+func (s *Server) transactionOverviewHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chainId, txHash, err := extractRequestParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	overviewResult := s.transactionOverview(ctx, chainId, txHash)
+
 	resultJSON, err := json.Marshal(overviewResult)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal overview results: %v", err)
@@ -455,8 +510,18 @@ func (s *Server) transactionOverviewHandler(ctx context.Context, request mcp.Cal
 	return mcp.NewToolResultText(string(resultJSON)), nil
 }
 
-// fetchChainList fetches the chain list from chainlist.org with retries
-func fetchChainList() ([]ChainData, error) {
+// fetchChainList fetches the chain list from chainlist.org with retries,
+// reusing a cached copy for up to chainListTTL when caching is enabled.
+func (s *Server) fetchChainList() ([]ChainData, error) {
+	if s.cache != nil {
+		if data, ok := s.cache.Get(chainListCacheKey); ok {
+			var chains []ChainData
+			if err := json.Unmarshal(data, &chains); err == nil {
+				return chains, nil
+			}
+		}
+	}
+
 	maxRetries := 3
 	var lastErr error
 
@@ -489,6 +554,10 @@ func fetchChainList() ([]ChainData, error) {
 			continue
 		}
 
+		if s.cache != nil {
+			_ = s.cache.Set(chainListCacheKey, body, chainListTTL)
+		}
+
 		// Success - return the chains
 		return chains, nil
 	}
@@ -539,13 +608,13 @@ func findChainByName(chains []ChainData, searchTerm string) (string, error) {
 // getChainIdByNameHandler handles requests to get a chain ID by name
 func (s *Server) getChainIdByNameHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	// Extract the chain name parameter
-	chainName, ok := request.Params.Arguments["name"].(string)
+	chainName, ok := request.GetArguments()["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name must be a string")
 	}
 
 	// Fetch the chain list
-	chains, err := fetchChainList()
+	chains, err := s.fetchChainList()
 	if err != nil {
 		return nil, err
 	}
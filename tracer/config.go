@@ -0,0 +1,41 @@
+package tracer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Config is the on-disk shape of the RPC endpoint mapping, keyed by chain
+// ID as a string since that's how JSON object keys work.
+type Config struct {
+	RPCEndpoints map[string]string `json:"rpcEndpoints"`
+}
+
+// LoadConfig reads a chainId -> rpcUrl mapping from a JSON file at path,
+// e.g.:
+//
+//	{"rpcEndpoints": {"1": "https://eth.llamarpc.com", "56": "https://bsc-dataseed.binance.org"}}
+func LoadConfig(path string) (map[int]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read RPC config %s: %v", path, err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse RPC config %s: %v", path, err)
+	}
+
+	endpoints := make(map[int]string, len(cfg.RPCEndpoints))
+	for chainIdStr, url := range cfg.RPCEndpoints {
+		chainId, err := strconv.Atoi(chainIdStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain ID %q in RPC config: %v", chainIdStr, err)
+		}
+		endpoints[chainId] = url
+	}
+
+	return endpoints, nil
+}
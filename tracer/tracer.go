@@ -0,0 +1,224 @@
+// Package tracer produces traces, state diffs, and balance changes by
+// talking directly to a user-configured JSON-RPC endpoint, as an
+// offline/self-hosted alternative to the Phalcon-backed DataSources in
+// the server package.
+package tracer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Supported debug_traceTransaction tracers.
+const (
+	CallTracer     = "callTracer"
+	PrestateTracer = "prestateTracer"
+	FourByteTracer = "4byteTracer"
+)
+
+// Tracer issues debug_traceTransaction calls against a JSON-RPC endpoint
+// resolved per chain ID. It does not use trace_replayTransaction, so an
+// endpoint without the debug namespace can't serve it.
+type Tracer struct {
+	endpoints map[int]string
+	client    *http.Client
+}
+
+// New creates a Tracer that resolves chain IDs to RPC endpoints using
+// endpoints, a chainId -> rpcUrl mapping typically loaded with
+// LoadConfig.
+func New(endpoints map[int]string) *Tracer {
+	return &Tracer{
+		endpoints: endpoints,
+		client:    &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// rpcURL resolves the endpoint to use for chainId, preferring an explicit
+// override (e.g. a per-call "rpcUrl" tool argument) over the configured
+// mapping.
+func (t *Tracer) rpcURL(chainId int, override string) (string, error) {
+	if override != "" {
+		return override, nil
+	}
+
+	url, ok := t.endpoints[chainId]
+	if !ok {
+		return "", fmt.Errorf("no RPC endpoint configured for chain %d", chainId)
+	}
+
+	return url, nil
+}
+
+// rpcRequest is a standard JSON-RPC 2.0 request envelope.
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+// rpcResponse is a standard JSON-RPC 2.0 response envelope.
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// call issues a JSON-RPC request against url and returns the raw result.
+func (t *Tracer) call(ctx context.Context, url, method string, params []any) (json.RawMessage, error) {
+	reqBody, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal RPC request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send RPC request to %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	var rpcResp rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return nil, fmt.Errorf("failed to decode RPC response: %v", err)
+	}
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("RPC error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+
+	return rpcResp.Result, nil
+}
+
+// Trace runs debug_traceTransaction with tracerName (CallTracer,
+// PrestateTracer, or FourByteTracer) against the RPC endpoint for
+// chainId, or rpcURL when non-empty.
+func (t *Tracer) Trace(ctx context.Context, chainId int, txHash, tracerName, rpcURL string) ([]byte, error) {
+	url, err := t.rpcURL(chainId, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if tracerName == "" {
+		tracerName = CallTracer
+	}
+
+	result, err := t.call(ctx, url, "debug_traceTransaction", []any{
+		txHash,
+		map[string]any{"tracer": tracerName},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// StateDiff runs debug_traceTransaction with the prestateTracer in diff
+// mode, reporting the before/after state of every account touched by the
+// transaction.
+func (t *Tracer) StateDiff(ctx context.Context, chainId int, txHash, rpcURL string) ([]byte, error) {
+	url, err := t.rpcURL(chainId, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := t.call(ctx, url, "debug_traceTransaction", []any{
+		txHash,
+		map[string]any{
+			"tracer": PrestateTracer,
+			"tracerConfig": map[string]any{
+				"diffMode": true,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// accountDiff is the shape debug_traceTransaction returns for each
+// touched account when prestateTracer runs in diff mode.
+type accountDiff struct {
+	Balance string `json:"balance"`
+}
+
+type prestateDiff struct {
+	Pre  map[string]accountDiff `json:"pre"`
+	Post map[string]accountDiff `json:"post"`
+}
+
+// BalanceChange derives per-account native balance changes from a
+// prestateTracer diff, since there's no dedicated JSON-RPC method for it.
+func (t *Tracer) BalanceChange(ctx context.Context, chainId int, txHash, rpcURL string) ([]byte, error) {
+	raw, err := t.StateDiff(ctx, chainId, txHash, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var diff prestateDiff
+	if err := json.Unmarshal(raw, &diff); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state diff: %v", err)
+	}
+
+	type balanceChange struct {
+		Address string `json:"address"`
+		Before  string `json:"before"`
+		After   string `json:"after"`
+	}
+
+	var changes []balanceChange
+	for addr, pre := range diff.Pre {
+		post, ok := diff.Post[addr]
+		if !ok || post.Balance == pre.Balance {
+			continue
+		}
+		changes = append(changes, balanceChange{
+			Address: addr,
+			Before:  pre.Balance,
+			After:   post.Balance,
+		})
+	}
+
+	// Accounts created during the transaction only appear in Post, with
+	// an implicit zero balance beforehand; without this they'd be
+	// silently dropped from the result.
+	for addr, post := range diff.Post {
+		if _, ok := diff.Pre[addr]; ok {
+			continue
+		}
+		if post.Balance == "" || post.Balance == "0x0" {
+			continue
+		}
+		changes = append(changes, balanceChange{
+			Address: addr,
+			Before:  "0x0",
+			After:   post.Balance,
+		})
+	}
+
+	result, err := json.Marshal(changes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal balance changes: %v", err)
+	}
+
+	return result, nil
+}